@@ -0,0 +1,88 @@
+package file_rw
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"errors"
+	"hash"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// HashAlgo selects the digest algorithm used by MultithreadedReadWithChecksum
+// and FastLoadTxtFileWithChecksum.
+type HashAlgo int8
+
+const (
+	HASH_MD5 HashAlgo = iota + 1
+	HASH_SHA256
+	HASH_BLAKE2B_256
+)
+
+func newHasher(algo HashAlgo) (hash.Hash, error) {
+	switch algo {
+	case HASH_MD5:
+		return md5.New(), nil
+	case HASH_SHA256:
+		return sha256.New(), nil
+	case HASH_BLAKE2B_256:
+		return blake2b.New256(nil)
+	default:
+		return nil, errors.New("unsupported hash algorithm")
+	}
+}
+
+// MultithreadedReadWithChecksum reads path exactly like MultithreadedRead,
+// then hashes the reassembled bytes with algo, so callers that need to
+// verify a large on-disk dataset don't have to read it a second time.
+//
+// MD5, SHA-256 and BLAKE2b-256 are all sequential digests - unlike a
+// Merkle/tree hash, their internal state can't be derived by combining
+// independently-hashed chunks, so the digest is computed in a single pass
+// over the data MultithreadedRead already assembled, rather than per chunk.
+//
+// An optional WithFS(fs) can be passed to target a backend other than the
+// local OS filesystem (see SetDefaultFS).
+func MultithreadedReadWithChecksum(path string, algo HashAlgo, opts ...Option) (*[]byte, []byte, error) {
+	data, err := MultithreadedRead(path, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hasher, err := newHasher(algo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, err = hasher.Write(*data); err != nil {
+		return nil, nil, err
+	}
+
+	return data, hasher.Sum(nil), nil
+}
+
+// FastLoadTxtFileWithChecksum behaves like FastLoadTxtFile, additionally
+// returning a digest of the raw file bytes computed with algo. Compare the
+// digest with an expected value using hmac.Equal.
+//
+// An optional WithFS(fs) can be passed to target a backend other than the
+// local OS filesystem (see SetDefaultFS).
+func FastLoadTxtFileWithChecksum(path string, allowEmptyLines bool, returnErrorOnEmptyFile bool, algo HashAlgo, opts ...Option) ([]string, []byte, error) {
+	rawDataPointer, checksum, err := MultithreadedReadWithChecksum(path, algo, opts...)
+
+	if err != nil {
+		return []string{}, nil, err
+	}
+
+	lines, err := splitToLines(rawDataPointer, allowEmptyLines)
+
+	if err != nil {
+		return []string{}, nil, err
+	}
+
+	if returnErrorOnEmptyFile && len(lines) == 0 {
+		return []string{}, nil, ErrFileEmpty
+	}
+
+	return lines, checksum, nil
+}
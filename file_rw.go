@@ -15,7 +15,7 @@ var ErrFileEmpty = errors.New("file empty")
 
 type FileRW struct {
 	bufferedWriter *bufio.Writer
-	fileResource   *os.File
+	fileResource   File
 }
 
 type WMode int8
@@ -42,29 +42,39 @@ const (
 //		CloseBufferedWrite() - Don't forget to close writes! Actually this flushes buffer and closes pointer to file
 //
 // (All other functions can be called without object instantiation (static call))
-func NewBufferedWriter(path string, mode WMode, createPathIfNotExists bool) (*FileRW, error) {
-	if err, _ := validateFilePath(path, false); err != nil {
+//
+// An optional WithFS(fs) can be passed to target a backend other than the
+// local OS filesystem (see SetDefaultFS).
+func NewBufferedWriter(path string, mode WMode, createPathIfNotExists bool, opts ...Option) (*FileRW, error) {
+	fs := resolveFS(opts)
+
+	if err, _ := validateFilePath(path, false, fs); err != nil {
 		return &FileRW{}, err
 	}
 
-	if f, err := createFileAtPath(path, mode, createPathIfNotExists); err != nil {
+	if f, err := createFileAtPath(path, mode, createPathIfNotExists, fs); err != nil {
 		return &FileRW{}, err
 	} else {
-		w := bufio.NewWriter(f)
+		w := getBufioWriter(f)
 		return &FileRW{bufferedWriter: w, fileResource: f}, nil
 	}
 }
 
 // FileWriteText - writes text string in variable "data" to file in variable "path". Path can be absolute or relative.
 // If createPathIfNotExists == true, an attempt will be made to recreate the specified directory structure.
-func FileWriteText(path string, data string, mode WMode, createPathIfNotExists bool) error {
-	if err, _ := validateFilePath(path, false); err != nil {
+//
+// An optional WithFS(fs) can be passed to target a backend other than the
+// local OS filesystem (see SetDefaultFS).
+func FileWriteText(path string, data string, mode WMode, createPathIfNotExists bool, opts ...Option) error {
+	fs := resolveFS(opts)
+
+	if err, _ := validateFilePath(path, false, fs); err != nil {
 		return err
 	}
 
-	if f, err := createFileAtPath(path, mode, createPathIfNotExists); err != nil {
+	if f, err := createFileAtPath(path, mode, createPathIfNotExists, fs); err != nil {
 		return err
-	} else if _, err = f.WriteString(data); err != nil {
+	} else if _, err = f.Write([]byte(data)); err != nil {
 		f.Close()
 		return err
 	} else {
@@ -75,15 +85,26 @@ func FileWriteText(path string, data string, mode WMode, createPathIfNotExists b
 
 // FileReadText - returns content of the file as a string
 // path - full (if start from /) or relative path to a file
-func FileReadText(path string) (string, error) {
-	if err, _ := validateFilePath(path, true); err != nil {
+//
+// An optional WithFS(fs) can be passed to target a backend other than the
+// local OS filesystem (see SetDefaultFS).
+func FileReadText(path string, opts ...Option) (string, error) {
+	fs := resolveFS(opts)
+
+	if err, _ := validateFilePath(path, true, fs); err != nil {
 		return "", err
 	}
 
-	if fileContentBytes, err := os.ReadFile(path); err != nil {
+	if f, err := fs.OpenFile(path, os.O_RDONLY, 0); err != nil {
 		return "", err
 	} else {
-		return string(fileContentBytes), nil
+		defer f.Close()
+
+		if fileContentBytes, err := io.ReadAll(f); err != nil {
+			return "", err
+		} else {
+			return string(fileContentBytes), nil
+		}
 	}
 }
 
@@ -99,6 +120,8 @@ func (frw *FileRW) CloseBufferedWrite() {
 	frw.bufferedWriter.Flush()
 	frw.fileResource.Close()
 
+	putBufioWriter(frw.bufferedWriter)
+
 	frw.bufferedWriter = nil
 	frw.fileResource = nil
 }
@@ -113,8 +136,11 @@ func (frw *FileRW) CloseBufferedWrite() {
 // for example, if file does not exist or is empty, this is not a reason to interrupt program execution, we can generate data and create/fill the file,
 // but if there is another error, like problem with permissions or syntax error in path, we really have a problem.
 // Use errors.Is(err, os.ErrNotExist) or errors.Is(err, file_rw.ErrFileEmpty) for convenient check for special error.
-func FastLoadTxtFile(path string, allowEmptyLines bool, returnErrorOnEmptyFile bool) ([]string, error) {
-	rawDataPointer, err := MultithreadedRead(path)
+//
+// An optional WithFS(fs) can be passed to target a backend other than the
+// local OS filesystem (see SetDefaultFS).
+func FastLoadTxtFile(path string, allowEmptyLines bool, returnErrorOnEmptyFile bool, opts ...Option) ([]string, error) {
+	rawDataPointer, err := MultithreadedRead(path, opts...)
 
 	if err != nil {
 		return []string{}, err
@@ -133,12 +159,19 @@ func FastLoadTxtFile(path string, allowEmptyLines bool, returnErrorOnEmptyFile b
 	return lines, nil
 }
 
-func MultithreadedRead(path string) (*[]byte, error) {
-	var f *os.File
+// MultithreadedRead reads the whole file at path into memory, splitting it
+// into chunks and reading them concurrently via ReadAt.
+//
+// An optional WithFS(fs) can be passed to target a backend other than the
+// local OS filesystem (see SetDefaultFS).
+func MultithreadedRead(path string, opts ...Option) (*[]byte, error) {
+	var f File
 	var err error
 	var fSize int64
 	var numberOfThreads int
 
+	fs := resolveFS(opts)
+
 	type filePart struct {
 		partNumber       int
 		startReadingByte int64
@@ -148,23 +181,17 @@ func MultithreadedRead(path string) (*[]byte, error) {
 		error            error
 	}
 
-	if err, fSize = validateFilePath(path, true); err != nil {
+	if err, fSize = validateFilePath(path, true, fs); err != nil {
 		return nil, err
 	}
 
-	if f, err = os.OpenFile(path, os.O_RDONLY, 0); err != nil {
+	if f, err = fs.OpenFile(path, os.O_RDONLY, 0); err != nil {
 		return nil, err
 	}
 
 	defer f.Close()
 
-	if fSize <= 1048576 { // 1MB
-		numberOfThreads = 1
-	} else if fSize <= 134217728 { // 134MB
-		numberOfThreads = 8
-	} else {
-		numberOfThreads = 16
-	}
+	numberOfThreads = numberOfThreadsForSize(fSize, 0)
 
 	// ======================================= MAKE A FILE READING PLAN ================================================
 	chunkSize := int64(math.Ceil(float64(fSize) / float64(numberOfThreads)))
@@ -176,7 +203,7 @@ func MultithreadedRead(path string) (*[]byte, error) {
 		fileInChunks[i] = filePart{
 			partNumber:       i,
 			startReadingByte: startIndex,
-			content:          make([]byte, chunkSize),
+			content:          getBuffer(int(chunkSize)),
 			lengthRequested:  chunkSize,
 		}
 
@@ -185,7 +212,7 @@ func MultithreadedRead(path string) (*[]byte, error) {
 			fileInChunks[i] = filePart{
 				partNumber:       i,
 				startReadingByte: startIndex,
-				content:          make([]byte, lastChunkSize),
+				content:          getBuffer(int(lastChunkSize)),
 				lengthRequested:  lastChunkSize,
 			}
 		}
@@ -197,7 +224,7 @@ func MultithreadedRead(path string) (*[]byte, error) {
 	// ======================================= PARALLEL READING ========================================================
 	dataChannel := make(chan filePart)
 
-	readChunkFn := func(f *os.File, partToRead filePart, dataChannel chan filePart) {
+	readChunkFn := func(f File, partToRead filePart, dataChannel chan filePart) {
 
 		// TODO: Investigate how ReadAt can change passed value if it not declared as a pointer?
 		length, err := f.ReadAt(partToRead.content, partToRead.startReadingByte)
@@ -245,6 +272,7 @@ func MultithreadedRead(path string) (*[]byte, error) {
 
 	for i := 0; i < numberOfThreads; i++ {
 		assembledFile = append(assembledFile, fileInChunks[i].content...)
+		putBuffer(fileInChunks[i].content)
 	}
 
 	if int64(len(assembledFile)) != fSize {
@@ -256,15 +284,20 @@ func MultithreadedRead(path string) (*[]byte, error) {
 }
 
 // FileWriteBytes put bytes slice to the file - either overwriting existing file or appending to the end of it.
-func FileWriteBytes(path string, data *[]byte, mode WMode, createPathIfNotExists bool) error {
+//
+// An optional WithFS(fs) can be passed to target a backend other than the
+// local OS filesystem (see SetDefaultFS).
+func FileWriteBytes(path string, data *[]byte, mode WMode, createPathIfNotExists bool, opts ...Option) error {
 	var err error
-	var f *os.File
+	var f File
 
-	if err, _ = validateFilePath(path, false); err != nil {
+	fs := resolveFS(opts)
+
+	if err, _ = validateFilePath(path, false, fs); err != nil {
 		return err
 	}
 
-	if f, err = createFileAtPath(path, mode, createPathIfNotExists); err != nil {
+	if f, err = createFileAtPath(path, mode, createPathIfNotExists, fs); err != nil {
 		return err
 	}
 
@@ -280,12 +313,17 @@ func FileWriteBytes(path string, data *[]byte, mode WMode, createPathIfNotExists
 }
 
 // FileOverwriteBytes overwrites data in file starting from specified byte.
-func FileOverwriteBytes(path string, fromByte int64, replacement *[]byte) error {
+//
+// An optional WithFS(fs) can be passed to target a backend other than the
+// local OS filesystem (see SetDefaultFS).
+func FileOverwriteBytes(path string, fromByte int64, replacement *[]byte, opts ...Option) error {
 	var size int64
 	var err error
-	var f *os.File
+	var f File
 
-	if err, size = validateFilePath(path, true); err != nil {
+	fs := resolveFS(opts)
+
+	if err, size = validateFilePath(path, true, fs); err != nil {
 		return err
 	}
 
@@ -295,7 +333,7 @@ func FileOverwriteBytes(path string, fromByte int64, replacement *[]byte) error
 	}
 
 	// ==================== OVERWRITE DATA =============================================================================
-	if f, err = os.OpenFile(path, os.O_WRONLY, 0644); err != nil {
+	if f, err = fs.OpenFile(path, os.O_WRONLY, 0644); err != nil {
 		return err
 	}
 
@@ -318,13 +356,18 @@ func FileOverwriteBytes(path string, fromByte int64, replacement *[]byte) error
 // FileInsertBytes inserts new data in file starting from specified byte. All existing data moved forward for len(insertion) bytes.
 // This function is effective when writing/inserting a piece of data at the end of file, when a small amount of data is written to disk.
 // When inserting at the beginning of a file, this function will not provide any benefit since it will actually overwrite (almost) the entire file.
-func FileInsertBytes(path string, fromByte int64, insertion *[]byte) error {
+//
+// An optional WithFS(fs) can be passed to target a backend other than the
+// local OS filesystem (see SetDefaultFS).
+func FileInsertBytes(path string, fromByte int64, insertion *[]byte, opts ...Option) error {
 	var size int64
 	var err error
-	var f *os.File
+	var f File
 	var remainder []byte
 
-	if err, size = validateFilePath(path, true); err != nil {
+	fs := resolveFS(opts)
+
+	if err, size = validateFilePath(path, true, fs); err != nil {
 		return err
 	}
 
@@ -334,7 +377,7 @@ func FileInsertBytes(path string, fromByte int64, insertion *[]byte) error {
 	}
 
 	// ==================== PART ONE: READ & REMEMBER SECOND PART ======================================================
-	if f, err = os.OpenFile(path, os.O_RDONLY, 0644); err != nil {
+	if f, err = fs.OpenFile(path, os.O_RDONLY, 0644); err != nil {
 		return err
 	}
 
@@ -352,7 +395,7 @@ func FileInsertBytes(path string, fromByte int64, insertion *[]byte) error {
 	// =================================================================================================================
 
 	// ==================== PART TWO: WRITE INSERTION ==================================================================
-	if f, err = os.OpenFile(path, os.O_WRONLY, 0644); err != nil {
+	if f, err = fs.OpenFile(path, os.O_WRONLY, 0644); err != nil {
 		return err
 	}
 
@@ -378,6 +421,160 @@ func FileInsertBytes(path string, fromByte int64, insertion *[]byte) error {
 	return nil
 }
 
+// FileDeleteBytes removes length bytes starting at fromByte, shifting
+// everything after the deleted range back by length bytes and truncating
+// the file. It is the inverse of FileInsertBytes.
+//
+// An optional WithFS(fs) can be passed to target a backend other than the
+// local OS filesystem (see SetDefaultFS).
+func FileDeleteBytes(path string, fromByte int64, length int64, opts ...Option) error {
+	var size int64
+	var err error
+	var f File
+	var tail []byte
+
+	fs := resolveFS(opts)
+
+	if err, size = validateFilePath(path, true, fs); err != nil {
+		return err
+	}
+
+	if fromByte+length > size {
+		return errors.New("incorrect delete, the gap is not allowed")
+	}
+
+	// ==================== PART ONE: READ & REMEMBER TAIL AFTER THE DELETED RANGE =====================================
+	if f, err = fs.OpenFile(path, os.O_RDONLY, 0644); err != nil {
+		return err
+	}
+
+	if _, err = f.Seek(fromByte+length, 0); err != nil {
+		return err
+	}
+
+	if tail, err = io.ReadAll(f); err != nil {
+		return err
+	}
+
+	if err = f.Close(); err != nil {
+		return err
+	}
+	// =================================================================================================================
+
+	// ==================== PART TWO: WRITE TAIL BACK STARTING AT fromByte, THEN TRUNCATE ===============================
+	if f, err = fs.OpenFile(path, os.O_WRONLY, 0644); err != nil {
+		return err
+	}
+
+	if _, err = f.Seek(fromByte, 0); err != nil {
+		return err
+	}
+
+	if _, err = f.Write(tail); err != nil {
+		return err
+	}
+
+	if err = f.Truncate(size - length); err != nil {
+		return err
+	}
+
+	if err = f.Close(); err != nil {
+		return err
+	}
+	// =================================================================================================================
+
+	return nil
+}
+
+// FileReplaceBytes replaces oldLen bytes starting at fromByte with
+// replacement. It composes a delete and an insert into a single pass over
+// the tail of the file, instead of rewriting it twice the way calling
+// FileDeleteBytes followed by FileInsertBytes would.
+//
+// An optional WithFS(fs) can be passed to target a backend other than the
+// local OS filesystem (see SetDefaultFS).
+func FileReplaceBytes(path string, fromByte int64, oldLen int64, replacement *[]byte, opts ...Option) error {
+	var size int64
+	var err error
+	var f File
+	var tail []byte
+
+	fs := resolveFS(opts)
+
+	if err, size = validateFilePath(path, true, fs); err != nil {
+		return err
+	}
+
+	if fromByte+oldLen > size {
+		return errors.New("incorrect replace, the gap is not allowed")
+	}
+
+	// ==================== PART ONE: READ & REMEMBER TAIL AFTER THE REPLACED RANGE ====================================
+	if f, err = fs.OpenFile(path, os.O_RDONLY, 0644); err != nil {
+		return err
+	}
+
+	if _, err = f.Seek(fromByte+oldLen, 0); err != nil {
+		return err
+	}
+
+	if tail, err = io.ReadAll(f); err != nil {
+		return err
+	}
+
+	if err = f.Close(); err != nil {
+		return err
+	}
+	// =================================================================================================================
+
+	// ==================== PART TWO: WRITE REPLACEMENT, THEN TAIL, THEN TRUNCATE ======================================
+	if f, err = fs.OpenFile(path, os.O_WRONLY, 0644); err != nil {
+		return err
+	}
+
+	if _, err = f.Seek(fromByte, 0); err != nil {
+		return err
+	}
+
+	if _, err = f.Write(*replacement); err != nil {
+		return err
+	}
+
+	if _, err = f.Write(tail); err != nil {
+		return err
+	}
+
+	if err = f.Truncate(fromByte + int64(len(*replacement)) + int64(len(tail))); err != nil {
+		return err
+	}
+
+	if err = f.Close(); err != nil {
+		return err
+	}
+	// =================================================================================================================
+
+	return nil
+}
+
+// numberOfThreadsForSize picks a concurrency level for a payload of size
+// bytes, using the same 1 MB / 134 MB thresholds MultithreadedRead has
+// always used. If requested is > 0 it is returned unchanged, so callers
+// (MultithreadedWrite, NewParallelWriter) can still force an exact thread
+// count and only fall back to the heuristic when they pass 0.
+func numberOfThreadsForSize(size int64, requested int) int {
+	if requested > 0 {
+		return requested
+	}
+
+	if size <= 1048576 { // 1MB
+		return 1
+	} else if size <= 134217728 { // 134MB
+		return 8
+	}
+
+	return 16
+}
+
 func splitToLines(data *[]byte, allowEmptyLines bool) ([]string, error) {
 	// Count EOL: https://stackoverflow.com/questions/24562942/golang-how-do-i-determine-the-number-of-lines-in-a-file-efficiently
 
@@ -389,7 +586,8 @@ func splitToLines(data *[]byte, allowEmptyLines bool) ([]string, error) {
 	fileLines := make([]string, 0, lineCount)
 
 	bytesReader := bytes.NewReader(*data)
-	bufReader := bufio.NewReader(bytesReader)
+	bufReader := getBufioReader(bytesReader)
+	defer putBufioReader(bufReader)
 
 	for {
 		line, err := bufReader.ReadString('\n')
@@ -427,7 +625,7 @@ func splitToLines(data *[]byte, allowEmptyLines bool) ([]string, error) {
 //
 //	error if path not valid (or file does not exist while it should) | nil
 //	file size in bytes if applicable
-func validateFilePath(path string, fileShouldExist bool) (error, int64) {
+func validateFilePath(path string, fileShouldExist bool, fs FS) (error, int64) {
 	if strings.HasSuffix(path, "/") {
 		return errors.New("full file path cannot end with \"/\", it should end with file name"), 0
 	}
@@ -437,8 +635,8 @@ func validateFilePath(path string, fileShouldExist bool) (error, int64) {
 	}
 
 	if fileShouldExist {
-		// "path" in os.Stat(path) can be either absolute or relative
-		if stat, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		// "path" in fs.Stat(path) can be either absolute or relative
+		if stat, err := fs.Stat(path); errors.Is(err, os.ErrNotExist) {
 			return err, 0
 		} else {
 			return nil, stat.Size()
@@ -448,7 +646,12 @@ func validateFilePath(path string, fileShouldExist bool) (error, int64) {
 	return nil, 0
 }
 
-func createFileAtPath(path string, mode WMode, createPathIfNotExists bool) (*os.File, error) {
+// resolvePathCreatingDirs normalizes path into the form fs.OpenFile expects
+// and, if createPathIfNotExists is true, creates every intermediate
+// directory the path implies. It is the path-handling half of
+// createFileAtPath, factored out so Open can reuse it with its own open
+// flags instead of createFileAtPath's write-only flag mapping.
+func resolvePathCreatingDirs(path string, createPathIfNotExists bool, fs FS) (string, error) {
 	prefix := ""
 	fullPath := ""
 
@@ -466,8 +669,8 @@ func createFileAtPath(path string, mode WMode, createPathIfNotExists bool) (*os.
 	if len(pathComponents) > 1 {
 		// Not only filename specified, but directory path too
 		if pathToDirectory := prefix + strings.Join(pathComponents[:len(pathComponents)-1], "/"); pathToDirectory != "" && createPathIfNotExists {
-			if err := os.MkdirAll(pathToDirectory, 0755); err != nil {
-				return nil, errors.New("cannot create directory by path \"" + pathToDirectory + "\"")
+			if err := fs.MkdirAll(pathToDirectory, 0755); err != nil {
+				return "", errors.New("cannot create directory by path \"" + pathToDirectory + "\"")
 			}
 		}
 
@@ -478,7 +681,16 @@ func createFileAtPath(path string, mode WMode, createPathIfNotExists bool) (*os.
 
 	} else {
 		// pathComponents by some reason empty - maybe path is empty too?
-		return nil, errors.New("incorrect or empty path")
+		return "", errors.New("incorrect or empty path")
+	}
+
+	return fullPath, nil
+}
+
+func createFileAtPath(path string, mode WMode, createPathIfNotExists bool, fs FS) (File, error) {
+	fullPath, err := resolvePathCreatingDirs(path, createPathIfNotExists, fs)
+	if err != nil {
+		return nil, err
 	}
 
 	fileMode := 0
@@ -491,5 +703,5 @@ func createFileAtPath(path string, mode WMode, createPathIfNotExists bool) (*os.
 		return nil, errors.New("not supported write mode")
 	}
 
-	return os.OpenFile(fullPath, fileMode, 0644)
+	return fs.OpenFile(fullPath, fileMode, 0644)
 }
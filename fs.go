@@ -0,0 +1,409 @@
+package file_rw
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+// File is the minimal surface this package needs from an open file handle.
+// *os.File satisfies it directly; MemFS and BasePathFS provide their own
+// implementations so the rest of file_rw never has to know which backend
+// it is talking to.
+type File interface {
+	io.Reader
+	io.Writer
+	io.Seeker
+	io.Closer
+	io.ReaderAt
+	io.WriterAt
+	Truncate(size int64) error
+	Sync() error
+	Name() string
+}
+
+// FS is the pluggable storage backend behind every file_rw function. It is
+// intentionally shaped like spf13/afero's Fs so existing afero backends are
+// easy to adapt if a consumer needs one we don't ship.
+type FS interface {
+	OpenFile(name string, flag int, perm os.FileMode) (File, error)
+	Stat(name string) (os.FileInfo, error)
+	MkdirAll(path string, perm os.FileMode) error
+	Remove(name string) error
+	Rename(oldname, newname string) error
+}
+
+// defaultFS is used by every public function that is not given an explicit
+// WithFS option, which keeps the package's historical static-call style working.
+var defaultFS FS = OsFS{}
+
+// SetDefaultFS replaces the package-wide default backend. Tests that want
+// every call in a file, without threading WithFS through each of them,
+// typically call this once in TestMain with a MemFS and restore OsFS after.
+func SetDefaultFS(fs FS) {
+	defaultFS = fs
+}
+
+// Option configures backend selection for a single file_rw call.
+type Option func(*options)
+
+type options struct {
+	fs FS
+}
+
+// WithFS overrides the backend for a single call, leaving the package-wide
+// default (see SetDefaultFS) untouched.
+func WithFS(fs FS) Option {
+	return func(o *options) {
+		o.fs = fs
+	}
+}
+
+func resolveFS(opts []Option) FS {
+	o := &options{fs: defaultFS}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o.fs
+}
+
+// ==================================================================================================================
+// OsFS - backs every file_rw call with the real local filesystem, exactly as
+// this package behaved before FS was introduced.
+// ==================================================================================================================
+
+// OsFS is the default FS, implemented on top of the standard os package.
+type OsFS struct{}
+
+func (OsFS) OpenFile(name string, flag int, perm os.FileMode) (File, error) {
+	return os.OpenFile(name, flag, perm)
+}
+
+func (OsFS) Stat(name string) (os.FileInfo, error) {
+	return os.Stat(name)
+}
+
+func (OsFS) MkdirAll(path string, perm os.FileMode) error {
+	return os.MkdirAll(path, perm)
+}
+
+func (OsFS) Remove(name string) error {
+	return os.Remove(name)
+}
+
+func (OsFS) Rename(oldname, newname string) error {
+	return os.Rename(oldname, newname)
+}
+
+// ==================================================================================================================
+// BasePathFS - delegates to another FS, transparently prefixing every path
+// with a fixed base, similar to a chroot.
+// ==================================================================================================================
+
+// BasePathFS chroots all operations performed on it to a base directory of
+// an underlying FS. It is handy in tests that want OsFS semantics but must
+// not touch anything outside a throwaway temp directory.
+type BasePathFS struct {
+	Source FS
+	Base   string
+}
+
+// NewBasePathFS returns a BasePathFS rooted at base on top of source.
+func NewBasePathFS(source FS, base string) *BasePathFS {
+	return &BasePathFS{Source: source, Base: base}
+}
+
+func (b *BasePathFS) realPath(name string) string {
+	// Cleaning name as an absolute path first resolves any ".." against "/",
+	// so the result can never climb above Base.
+	return path.Join(b.Base, path.Clean("/"+name))
+}
+
+func (b *BasePathFS) OpenFile(name string, flag int, perm os.FileMode) (File, error) {
+	return b.Source.OpenFile(b.realPath(name), flag, perm)
+}
+
+func (b *BasePathFS) Stat(name string) (os.FileInfo, error) {
+	return b.Source.Stat(b.realPath(name))
+}
+
+func (b *BasePathFS) MkdirAll(path string, perm os.FileMode) error {
+	return b.Source.MkdirAll(b.realPath(path), perm)
+}
+
+func (b *BasePathFS) Remove(name string) error {
+	return b.Source.Remove(b.realPath(name))
+}
+
+func (b *BasePathFS) Rename(oldname, newname string) error {
+	return b.Source.Rename(b.realPath(oldname), b.realPath(newname))
+}
+
+// ==================================================================================================================
+// MemFS - a minimal in-memory FS, used to unit-test file_rw without touching
+// disk. It mimics the subset of os semantics this package relies on: ENOENT
+// on a missing Stat/OpenFile-without-O_CREATE, directory bookkeeping for
+// MkdirAll, and byte-for-byte ReadAt/WriteAt/Truncate behaviour.
+// ==================================================================================================================
+
+type memFileData struct {
+	mu      sync.Mutex
+	name    string
+	content []byte
+	modTime time.Time
+}
+
+// MemFS is a concurrency-safe, in-memory FS implementation.
+type MemFS struct {
+	mu    sync.Mutex
+	files map[string]*memFileData
+	dirs  map[string]bool
+}
+
+// NewMemFS returns an empty in-memory filesystem.
+func NewMemFS() *MemFS {
+	return &MemFS{
+		files: make(map[string]*memFileData),
+		dirs:  map[string]bool{"/": true, "": true},
+	}
+}
+
+func (m *MemFS) OpenFile(name string, flag int, perm os.FileMode) (File, error) {
+	m.mu.Lock()
+	data, exists := m.files[name]
+
+	if !exists {
+		if flag&os.O_CREATE == 0 {
+			m.mu.Unlock()
+			return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+		}
+
+		data = &memFileData{name: name, modTime: time.Now()}
+		m.files[name] = data
+	}
+	m.mu.Unlock()
+
+	if flag&os.O_TRUNC != 0 {
+		data.mu.Lock()
+		data.content = nil
+		data.mu.Unlock()
+	}
+
+	// Like os.OpenFile, the handle starts at offset 0 even with O_APPEND;
+	// appendMode only moves the position to end of file before each Write.
+	return &memFile{data: data, pos: 0, appendMode: flag&os.O_APPEND != 0}, nil
+}
+
+func (m *MemFS) Stat(name string) (os.FileInfo, error) {
+	m.mu.Lock()
+	data, exists := m.files[name]
+	_, isDir := m.dirs[name]
+	m.mu.Unlock()
+
+	if isDir {
+		return memFileInfo{name: path.Base(name), isDir: true}, nil
+	}
+
+	if !exists {
+		return nil, &os.PathError{Op: "stat", Path: name, Err: os.ErrNotExist}
+	}
+
+	data.mu.Lock()
+	defer data.mu.Unlock()
+
+	return memFileInfo{name: path.Base(name), size: int64(len(data.content)), modTime: data.modTime}, nil
+}
+
+func (m *MemFS) MkdirAll(dirPath string, perm os.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	accumulated := ""
+	for _, part := range strings.Split(strings.TrimPrefix(dirPath, "/"), "/") {
+		if part == "" {
+			continue
+		}
+
+		accumulated += "/" + part
+		m.dirs[accumulated] = true
+	}
+
+	return nil
+}
+
+func (m *MemFS) Remove(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.files[name]; !exists {
+		return &os.PathError{Op: "remove", Path: name, Err: os.ErrNotExist}
+	}
+
+	delete(m.files, name)
+
+	return nil
+}
+
+func (m *MemFS) Rename(oldname, newname string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	data, exists := m.files[oldname]
+	if !exists {
+		return &os.PathError{Op: "rename", Path: oldname, Err: os.ErrNotExist}
+	}
+
+	data.mu.Lock()
+	data.name = newname
+	data.mu.Unlock()
+
+	m.files[newname] = data
+	delete(m.files, oldname)
+
+	return nil
+}
+
+// memFile implements File on top of a shared memFileData, so two handles
+// opened for the same name see each other's writes, like real os.File
+// descriptors on the same inode.
+type memFile struct {
+	data       *memFileData
+	pos        int64
+	appendMode bool
+}
+
+func (f *memFile) Read(p []byte) (int, error) {
+	f.data.mu.Lock()
+	defer f.data.mu.Unlock()
+
+	if f.pos >= int64(len(f.data.content)) {
+		return 0, io.EOF
+	}
+
+	n := copy(p, f.data.content[f.pos:])
+	f.pos += int64(n)
+
+	return n, nil
+}
+
+func (f *memFile) ReadAt(p []byte, off int64) (int, error) {
+	f.data.mu.Lock()
+	defer f.data.mu.Unlock()
+
+	if off >= int64(len(f.data.content)) {
+		return 0, io.EOF
+	}
+
+	n := copy(p, f.data.content[off:])
+
+	if n < len(p) {
+		return n, io.EOF
+	}
+
+	return n, nil
+}
+
+func (f *memFile) Write(p []byte) (int, error) {
+	f.data.mu.Lock()
+	defer f.data.mu.Unlock()
+
+	if f.appendMode {
+		f.pos = int64(len(f.data.content))
+	}
+
+	n := f.growAndCopyLocked(p, f.pos)
+	f.pos += int64(n)
+	f.data.modTime = time.Now()
+
+	return n, nil
+}
+
+func (f *memFile) WriteAt(p []byte, off int64) (int, error) {
+	f.data.mu.Lock()
+	defer f.data.mu.Unlock()
+
+	n := f.growAndCopyLocked(p, off)
+	f.data.modTime = time.Now()
+
+	return n, nil
+}
+
+// growAndCopyLocked must be called with f.data.mu held.
+func (f *memFile) growAndCopyLocked(p []byte, off int64) int {
+	end := off + int64(len(p))
+
+	if end > int64(len(f.data.content)) {
+		grown := make([]byte, end)
+		copy(grown, f.data.content)
+		f.data.content = grown
+	}
+
+	return copy(f.data.content[off:], p)
+}
+
+func (f *memFile) Seek(offset int64, whence int) (int64, error) {
+	f.data.mu.Lock()
+	size := int64(len(f.data.content))
+	f.data.mu.Unlock()
+
+	switch whence {
+	case io.SeekStart:
+		f.pos = offset
+	case io.SeekCurrent:
+		f.pos += offset
+	case io.SeekEnd:
+		f.pos = size + offset
+	default:
+		return 0, errors.New("memFile.Seek: invalid whence")
+	}
+
+	return f.pos, nil
+}
+
+func (f *memFile) Truncate(size int64) error {
+	f.data.mu.Lock()
+	defer f.data.mu.Unlock()
+
+	if size <= int64(len(f.data.content)) {
+		f.data.content = f.data.content[:size]
+		return nil
+	}
+
+	grown := make([]byte, size)
+	copy(grown, f.data.content)
+	f.data.content = grown
+
+	return nil
+}
+
+func (f *memFile) Sync() error {
+	return nil
+}
+
+func (f *memFile) Close() error {
+	return nil
+}
+
+func (f *memFile) Name() string {
+	return f.data.name
+}
+
+type memFileInfo struct {
+	name    string
+	size    int64
+	modTime time.Time
+	isDir   bool
+}
+
+func (i memFileInfo) Name() string       { return i.name }
+func (i memFileInfo) Size() int64        { return i.size }
+func (i memFileInfo) Mode() os.FileMode  { return 0644 }
+func (i memFileInfo) ModTime() time.Time { return i.modTime }
+func (i memFileInfo) IsDir() bool        { return i.isDir }
+func (i memFileInfo) Sys() interface{}   { return nil }
@@ -0,0 +1,120 @@
+package file_rw
+
+import "testing"
+
+func TestFileWriteReadTextWithMemFS(t *testing.T) {
+	mem := NewMemFS()
+	path := "/dir/sub/file.txt"
+
+	if err := FileWriteText(path, "hello memfs\n", WMODE_OVERWRITE, true, WithFS(mem)); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := FileReadText(path, WithFS(mem))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "hello memfs\n"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestMultithreadedReadWithMemFS(t *testing.T) {
+	mem := NewMemFS()
+	path := "/big.bin"
+
+	payload := make([]byte, 3*1024*1024)
+	for i := range payload {
+		payload[i] = byte(i)
+	}
+
+	if err := FileWriteBytes(path, &payload, WMODE_OVERWRITE, true, WithFS(mem)); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := MultithreadedRead(path, WithFS(mem))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(*data) != len(payload) {
+		t.Fatalf("got %d bytes, want %d", len(*data), len(payload))
+	}
+
+	for i := range payload {
+		if (*data)[i] != payload[i] {
+			t.Fatalf("byte %d mismatch: got %d, want %d", i, (*data)[i], payload[i])
+		}
+	}
+}
+
+func TestFileInsertDeleteReplaceBytesWithMemFS(t *testing.T) {
+	mem := NewMemFS()
+	path := "/edit.txt"
+
+	if err := FileWriteText(path, "Line 1\nLine 2\nLine 4\n", WMODE_OVERWRITE, true, WithFS(mem)); err != nil {
+		t.Fatal(err)
+	}
+
+	insertion := []byte("Line 3\n")
+	if err := FileInsertBytes(path, int64(len("Line 1\nLine 2\n")), &insertion, WithFS(mem)); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := FileReadText(path, WithFS(mem))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "Line 1\nLine 2\nLine 3\nLine 4\n"; got != want {
+		t.Fatalf("after insert got %q, want %q", got, want)
+	}
+
+	if err = FileDeleteBytes(path, int64(len("Line 1\nLine 2\n")), int64(len("Line 3\n")), WithFS(mem)); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err = FileReadText(path, WithFS(mem))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "Line 1\nLine 2\nLine 4\n"; got != want {
+		t.Fatalf("after delete got %q, want %q", got, want)
+	}
+
+	replacement := []byte("Line ONE")
+	if err = FileReplaceBytes(path, 0, int64(len("Line 1")), &replacement, WithFS(mem)); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err = FileReadText(path, WithFS(mem))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "Line ONE\nLine 2\nLine 4\n"; got != want {
+		t.Fatalf("after replace got %q, want %q", got, want)
+	}
+}
+
+func TestBasePathFSChrootsPaths(t *testing.T) {
+	mem := NewMemFS()
+	base := NewBasePathFS(mem, "/sandbox")
+
+	if err := FileWriteText("/nested/file.txt", "chrooted\n", WMODE_OVERWRITE, true, WithFS(base)); err != nil {
+		t.Fatal(err)
+	}
+
+	// The write above must have landed under the base prefix on the
+	// underlying FS, not at the path as given to BasePathFS.
+	got, err := FileReadText("/sandbox/nested/file.txt", WithFS(mem))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "chrooted\n"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,107 @@
+package file_rw
+
+import (
+	"bufio"
+	"io"
+	"sync"
+)
+
+// canonicalChunkSizes are the bucket sizes getBuffer/putBuffer recycle.
+// A request for a size larger than the biggest bucket falls back to a plain
+// allocation - there is little point pooling multi-hundred-megabyte chunks.
+var canonicalChunkSizes = []int{
+	64 * 1024,
+	128 * 1024,
+	256 * 1024,
+	512 * 1024,
+	1024 * 1024,
+	2 * 1024 * 1024,
+	4 * 1024 * 1024,
+	8 * 1024 * 1024,
+}
+
+var bufferPools = make([]sync.Pool, len(canonicalChunkSizes))
+
+func init() {
+	for i, size := range canonicalChunkSizes {
+		size := size
+		bufferPools[i].New = func() interface{} {
+			return make([]byte, size)
+		}
+	}
+}
+
+// bucketIndex returns the index of the smallest canonical bucket that can
+// hold size bytes, or -1 if size is bigger than every bucket.
+func bucketIndex(size int) int {
+	for i, bucketSize := range canonicalChunkSizes {
+		if size <= bucketSize {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// getBuffer returns a []byte of exactly size bytes, reusing a pooled buffer
+// from the nearest canonical bucket when one fits.
+func getBuffer(size int) []byte {
+	idx := bucketIndex(size)
+	if idx == -1 {
+		return make([]byte, size)
+	}
+
+	return bufferPools[idx].Get().([]byte)[:size]
+}
+
+// putBuffer returns b to its canonical pool. Buffers whose capacity doesn't
+// match a canonical bucket exactly (e.g. ones returned by plain make() for
+// an oversized request) are left for the garbage collector.
+func putBuffer(b []byte) {
+	idx := bucketIndex(cap(b))
+	if idx == -1 || canonicalChunkSizes[idx] != cap(b) {
+		return
+	}
+
+	bufferPools[idx].Put(b[:cap(b)])
+}
+
+var bufioReaderPool = sync.Pool{
+	New: func() interface{} {
+		return bufio.NewReader(nil)
+	},
+}
+
+var bufioWriterPool = sync.Pool{
+	New: func() interface{} {
+		return bufio.NewWriter(nil)
+	},
+}
+
+// getBufioReader returns a pooled *bufio.Reader reset to read from r.
+func getBufioReader(r io.Reader) *bufio.Reader {
+	br := bufioReaderPool.Get().(*bufio.Reader)
+	br.Reset(r)
+
+	return br
+}
+
+// putBufioReader releases br back to the pool. br must not be used afterwards.
+func putBufioReader(br *bufio.Reader) {
+	br.Reset(nil)
+	bufioReaderPool.Put(br)
+}
+
+// getBufioWriter returns a pooled *bufio.Writer reset to write to w.
+func getBufioWriter(w io.Writer) *bufio.Writer {
+	bw := bufioWriterPool.Get().(*bufio.Writer)
+	bw.Reset(w)
+
+	return bw
+}
+
+// putBufioWriter releases bw back to the pool. bw must not be used afterwards.
+func putBufioWriter(bw *bufio.Writer) {
+	bw.Reset(nil)
+	bufioWriterPool.Put(bw)
+}